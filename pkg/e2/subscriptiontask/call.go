@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package subscriptiontask
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures retry/backoff behavior for a reconnect loop
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first. A
+	// value of 0 means no limit; a value of 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries
+	MaxBackoff time.Duration
+
+	// BackoffMultiplier is applied to the backoff after each attempt
+	BackoffMultiplier float64
+
+	// Jitter is the fraction of the computed backoff (0-1) to randomize
+	Jitter float64
+}
+
+// defaultRetryPolicy is used by reconcileStore, which has no caller-facing option for it
+var defaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       0, // unlimited
+	InitialBackoff:    100 * time.Millisecond,
+	MaxBackoff:        5 * time.Second,
+	BackoffMultiplier: 2,
+	Jitter:            0.2,
+}
+
+// backoff computes the delay before the given attempt (0-indexed) according to policy
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 0; i < attempt; i++ {
+		d *= p.BackoffMultiplier
+	}
+	if max := float64(p.MaxBackoff); p.MaxBackoff > 0 && d > max {
+		d = max
+	}
+	if p.Jitter > 0 {
+		delta := d * p.Jitter
+		d = d - delta + rand.Float64()*2*delta
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package subscriptiontask
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        1 * time.Second,
+		BackoffMultiplier: 2,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(0))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(2))
+}
+
+func TestRetryPolicyBackoffCapsAtMaxBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        300 * time.Millisecond,
+		BackoffMultiplier: 2,
+	}
+
+	assert.Equal(t, 300*time.Millisecond, policy.backoff(5))
+}
+
+func TestRetryPolicyBackoffJitter(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        time.Second,
+		BackoffMultiplier: 2,
+		Jitter:            0.2,
+	}
+
+	for i := 0; i < 20; i++ {
+		d := policy.backoff(0)
+		assert.GreaterOrEqual(t, d, 80*time.Millisecond)
+		assert.LessOrEqual(t, d, 120*time.Millisecond)
+	}
+}
@@ -5,16 +5,24 @@
 package subscriptiontask
 
 import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
 	epapi "github.com/onosproject/onos-e2sub/api/e2/endpoint/v1beta1"
 	subapi "github.com/onosproject/onos-e2sub/api/e2/subscription/v1beta1"
 	"github.com/onosproject/onos-ric-sdk-go/pkg/e2"
-	"io"
 
 	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
 )
 
 var log = logging.GetLogger("e2", "subscription", "client")
@@ -39,10 +47,11 @@ type watchOptions struct {
 	endpointID     epapi.ID
 }
 
-// FilterOption is an option for filtering List/Watch calls
+// FilterOption is an option for filtering List/Watch/Subscribe calls
 type FilterOption interface {
 	ListOption
 	WatchOption
+	SubscribeOption
 }
 
 // WithSubscriptionID creates an option for filtering by subscription ID
@@ -64,6 +73,10 @@ func (o *filterSubscriptionOption) applyWatch(options *watchOptions) {
 	options.subscriptionID = o.subID
 }
 
+func (o *filterSubscriptionOption) applySubscribe(options *subscribeOptions) {
+	options.subscriptionID = o.subID
+}
+
 // WithEndpointID creates an option for filtering by endpoint ID
 func WithEndpointID(id epapi.ID) FilterOption {
 	return &filterEndpointOption{
@@ -83,6 +96,10 @@ func (o *filterEndpointOption) applyWatch(options *watchOptions) {
 	options.endpointID = o.epID
 }
 
+func (o *filterEndpointOption) applySubscribe(options *subscribeOptions) {
+	options.endpointID = o.epID
+}
+
 // Client is an E2 subscription service client interface
 type Client interface {
 	// Get returns a subscription based on a given subscription ID
@@ -93,44 +110,322 @@ type Client interface {
 
 	// Watch watches the subscription changes
 	Watch(ctx context.Context, ch chan<- subtaskapi.Event, opts ...WatchOption) error
+
+	// Subscribe is a resilient alternative to Watch: it reconnects the
+	// underlying stream on transient errors, resumes from the last-observed
+	// state so reconnects do not lose events, and dispatches events to
+	// handler using a bounded worker pool. It returns a channel on which a
+	// single terminal error is reported if the subscription cannot continue.
+	//
+	// Create/Update/Delete/Ack are intentionally not part of this interface:
+	// onos-e2sub's task/v1beta1 service only exposes Get/List/Watch, since
+	// subscription tasks are created and reconciled server-side rather than
+	// by xApp clients. Add them here once (and if) that API grows those RPCs.
+	Subscribe(ctx context.Context, handler Handler, opts ...SubscribeOption) (<-chan error, error)
 }
 
 // localClient subscription client
 type localClient struct {
 	conn   *grpc.ClientConn
 	client subtaskapi.E2SubscriptionTaskServiceClient
+	store  Store
+
+	// lifetimeCtx is canceled by Close and governs background work - namely
+	// reconcileStore - that must outlive the ctx passed into NewClient, which
+	// callers commonly scope to setup/request rather than to the client.
+	lifetimeCtx    context.Context
+	cancelLifetime context.CancelFunc
 }
 
-// Destination determines subscription service endpoint
-type Destination struct {
-	// Addrs a slice of addresses by which a subscription service may be reached.
-	Addrs []string
+// ClientOption is an option for configuring NewClient
+type ClientOption interface {
+	applyClient(*clientOptions)
 }
 
-// NewClient creates a new subscribe service client
-func NewClient(ctx context.Context, dst Destination) (Client, error) {
-	tlsConfig, err := e2.GetClientCredentials()
-	if err != nil {
-		return &localClient{}, err
+type clientOptions struct {
+	addrs       []string
+	tlsConfig   *tls.Config
+	insecure    bool
+	keepalive   keepalive.ClientParameters
+	dialTimeout time.Duration
+	userAgent   string
+	store       Store
+}
+
+// WithAddrs configures the set of addresses by which the onos-e2sub
+// subscription task service may be reached. When more than one address is
+// given, the client load-balances RPCs across all of them round-robin and
+// fails over automatically as addresses become unreachable.
+func WithAddrs(addrs ...string) ClientOption {
+	return &addrsOption{addrs: addrs}
+}
+
+type addrsOption struct {
+	addrs []string
+}
+
+func (o *addrsOption) applyClient(options *clientOptions) {
+	options.addrs = o.addrs
+}
+
+// WithTLS configures the TLS client config used to dial onos-e2sub
+func WithTLS(tlsConfig *tls.Config) ClientOption {
+	return &tlsOption{tlsConfig: tlsConfig}
+}
+
+type tlsOption struct {
+	tlsConfig *tls.Config
+}
+
+func (o *tlsOption) applyClient(options *clientOptions) {
+	options.tlsConfig = o.tlsConfig
+}
+
+// WithInsecure disables transport security, for use against a local
+// onos-e2sub instance during development.
+func WithInsecure() ClientOption {
+	return &insecureOption{}
+}
+
+type insecureOption struct{}
+
+func (o *insecureOption) applyClient(options *clientOptions) {
+	options.insecure = true
+}
+
+// WithKeepalive configures gRPC keepalive pings on the client connection
+func WithKeepalive(params keepalive.ClientParameters) ClientOption {
+	return &keepaliveOption{params: params}
+}
+
+type keepaliveOption struct {
+	params keepalive.ClientParameters
+}
+
+func (o *keepaliveOption) applyClient(options *clientOptions) {
+	options.keepalive = o.params
+}
+
+// WithDialTimeout bounds how long NewClient waits for the initial connection
+func WithDialTimeout(timeout time.Duration) ClientOption {
+	return &dialTimeoutOption{timeout: timeout}
+}
+
+type dialTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *dialTimeoutOption) applyClient(options *clientOptions) {
+	options.dialTimeout = o.timeout
+}
+
+// WithUserAgent sets the user agent reported to onos-e2sub
+func WithUserAgent(userAgent string) ClientOption {
+	return &userAgentOption{userAgent: userAgent}
+}
+
+type userAgentOption struct {
+	userAgent string
+}
+
+func (o *userAgentOption) applyClient(options *clientOptions) {
+	options.userAgent = o.userAgent
+}
+
+// WithStore configures a Store the client uses to cache the last-known set
+// of subscription tasks and the watch cursor. This gives an xApp a warm
+// cache across restarts and, with a shared backend such as etcd, lets
+// multiple xApp replicas share task assignment state.
+func WithStore(store Store) ClientOption {
+	return &storeOption{store: store}
+}
+
+type storeOption struct {
+	store Store
+}
+
+func (o *storeOption) applyClient(options *clientOptions) {
+	options.store = o.store
+}
+
+// resolverScheme is the scheme used for the static, per-client resolver that
+// feeds NewClient's address slice to gRPC's round-robin balancer.
+const resolverScheme = "onos-e2sub-subscriptiontask"
+
+// NewClient creates a new subscribe service client. At least one address
+// must be configured via WithAddrs.
+func NewClient(ctx context.Context, opts ...ClientOption) (Client, error) {
+	options := &clientOptions{
+		dialTimeout: 15 * time.Second,
+		keepalive: keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		},
+	}
+	for _, opt := range opts {
+		opt.applyClient(options)
+	}
+
+	if len(options.addrs) == 0 {
+		return &localClient{}, errors.New("no addresses configured; use subscriptiontask.WithAddrs")
+	}
+
+	resolverAddrs := make([]resolver.Address, len(options.addrs))
+	for i, addr := range options.addrs {
+		resolverAddrs[i] = resolver.Address{Addr: addr}
+	}
+	builder := manual.NewBuilderWithScheme(resolverScheme)
+	builder.InitialState(resolver.State{Addresses: resolverAddrs})
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithResolvers(builder),
+		grpc.WithBlock(),
+		grpc.WithKeepaliveParams(options.keepalive),
+		grpc.WithDefaultServiceConfig(`{
+			"loadBalancingConfig": [{"round_robin":{}}],
+			"healthCheckConfig": {"serviceName": ""}
+		}`),
 	}
 
-	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+	if options.userAgent != "" {
+		dialOpts = append(dialOpts, grpc.WithUserAgent(options.userAgent))
 	}
 
-	conn, err := grpc.DialContext(ctx, dst.Addrs[0], opts...)
+	if options.insecure {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	} else {
+		tlsConfig := options.tlsConfig
+		if tlsConfig == nil {
+			var err error
+			tlsConfig, err = e2.GetClientCredentials()
+			if err != nil {
+				return &localClient{}, err
+			}
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, options.dialTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(dialCtx, resolverScheme+":///subscriptiontask", dialOpts...)
 	if err != nil {
 		return &localClient{}, err
 	}
 
 	cl := subtaskapi.NewE2SubscriptionTaskServiceClient(conn)
 
-	client := localClient{
-		client: cl,
-		conn:   conn,
+	lifetimeCtx, cancelLifetime := context.WithCancel(context.Background())
+	client := &localClient{
+		client:         cl,
+		conn:           conn,
+		store:          options.store,
+		lifetimeCtx:    lifetimeCtx,
+		cancelLifetime: cancelLifetime,
+	}
+
+	if client.store != nil {
+		if err := client.hydrate(client.lifetimeCtx); err != nil {
+			log.Warn("failed to hydrate subscription task cache from store", err)
+		}
+		go client.reconcileStore(client.lifetimeCtx)
+	}
+
+	return client, nil
+}
+
+// hydrate populates store with the current set of subscription tasks known
+// to onos-e2sub, so callers reading through the store see a consistent view
+// without waiting on the background reconcile loop.
+func (c *localClient) hydrate(ctx context.Context) error {
+	tasks, err := c.List(ctx)
+	if err != nil {
+		return err
+	}
+	current := make(map[subtaskapi.ID]bool, len(tasks))
+	for _, task := range tasks {
+		current[task.ID] = true
+		if err := c.store.Put(ctx, task); err != nil {
+			return err
+		}
+	}
+	cached, err := c.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, task := range cached {
+		if !current[task.ID] {
+			if err := c.store.Delete(ctx, task.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileStore keeps store up to date for the lifetime of the client,
+// reconnecting its Watch stream with backoff on transient errors. The
+// cursor is persisted as each event is processed so Store's Cursor/
+// SetCursor accessors are meaningful to callers, e.g. for observing
+// reconciliation progress or for a Store backend that can itself resume
+// from it; onos-e2sub's Watch RPC does not yet accept a resume point, so
+// a restart still re-hydrates from a full List rather than from the cursor.
+func (c *localClient) reconcileStore(ctx context.Context) {
+	if cursor, err := c.store.Cursor(ctx); err != nil {
+		log.Error("failed to read subscription task store cursor", err)
+	} else if cursor != "" {
+		log.Infof("subscription task store was last reconciled through cursor %s", cursor)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(defaultRetryPolicy.backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+		}
+
+		if err := c.watchIntoStore(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error("subscription task store reconciliation stream ended, reconnecting", err)
+			continue
+		}
+		return
+	}
+}
+
+// watchIntoStore runs a single Watch session, applying every event to store
+// and persisting the watch cursor as it goes.
+func (c *localClient) watchIntoStore(ctx context.Context) error {
+	ch := make(chan subtaskapi.Event)
+	if err := c.Watch(ctx, ch); err != nil {
+		return err
 	}
 
-	return &client, nil
+	for event := range ch {
+		var err error
+		switch event.Type {
+		case subtaskapi.EventType_REMOVED:
+			err = c.store.Delete(ctx, event.Task.ID)
+		default:
+			err = c.store.Put(ctx, event.Task)
+		}
+		if err != nil {
+			log.Error("failed to reconcile subscription task store", err)
+			continue
+		}
+
+		cursor := fmt.Sprintf("%s@%d", event.Task.ID, event.Task.Revision)
+		if err := c.store.SetCursor(ctx, cursor); err != nil {
+			log.Error("failed to persist subscription task store cursor", err)
+		}
+	}
+	return io.EOF
 }
 
 // Get returns information about a subscription
@@ -185,27 +480,27 @@ func (c *localClient) Watch(ctx context.Context, ch chan<- subtaskapi.Event, opt
 	}
 
 	go func() {
+		defer close(ch)
 		for {
 			resp, err := stream.Recv()
-			if err == io.EOF || err == context.Canceled {
-				close(ch)
-				break
-			}
-
 			if err != nil {
-				log.Error("an error occurred in receiving subscription changes", err)
+				if err != io.EOF && err != context.Canceled {
+					log.Error("an error occurred in receiving subscription changes", err)
+				}
+				return
 			}
-
 			ch <- resp.Event
-
 		}
-
 	}()
 	return nil
 }
 
-// Close closes the client connection
+// Close stops background store reconciliation, if any, and closes the
+// client connection.
 func (c *localClient) Close() error {
+	if c.cancelLifetime != nil {
+		c.cancelLifetime()
+	}
 	return c.conn.Close()
 }
 
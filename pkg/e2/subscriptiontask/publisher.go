@@ -0,0 +1,269 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package subscriptiontask
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
+	"github.com/nats-io/nats.go"
+	"golang.org/x/net/context"
+)
+
+// defaultSubjectPrefix namespaces subscription task events on the NATS bus
+const defaultSubjectPrefix = "onos.e2sub.subscriptiontask"
+
+// Codec encodes and decodes subscription task events for transport over NATS
+type Codec interface {
+	Encode(event subtaskapi.Event) ([]byte, error)
+	Decode(data []byte) (subtaskapi.Event, error)
+}
+
+// ProtoCodec encodes events as protobuf, matching the wire format gRPC uses
+var ProtoCodec Codec = protoCodec{}
+
+type protoCodec struct{}
+
+func (protoCodec) Encode(event subtaskapi.Event) ([]byte, error) {
+	return proto.Marshal(&event)
+}
+
+func (protoCodec) Decode(data []byte) (subtaskapi.Event, error) {
+	var event subtaskapi.Event
+	err := proto.Unmarshal(data, &event)
+	return event, err
+}
+
+// JSONCodec encodes events as JSON, for consumers that do not link the
+// generated protobuf stubs.
+var JSONCodec Codec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(event subtaskapi.Event) ([]byte, error) {
+	return json.Marshal(&event)
+}
+
+func (jsonCodec) Decode(data []byte) (subtaskapi.Event, error) {
+	var event subtaskapi.Event
+	err := json.Unmarshal(data, &event)
+	return event, err
+}
+
+// EventBridgeConfig is shared by EventPublisher and EventSource
+type EventBridgeConfig struct {
+	// URL is the NATS server URL, e.g. "nats://localhost:4222"
+	URL string
+
+	// SubjectPrefix namespaces the subjects events are published to/read
+	// from. Each event is published to "<prefix>.<subscriptionID>.<endpointID>".
+	SubjectPrefix string
+
+	// Codec encodes/decodes events on the wire. Defaults to ProtoCodec.
+	Codec Codec
+
+	// JetStream enables publishing to / consuming from a durable JetStream
+	// stream instead of NATS core pub/sub, so late subscribers can replay
+	// recent events.
+	JetStream bool
+
+	// StreamName is the JetStream stream name when JetStream is enabled.
+	StreamName string
+}
+
+func (c *EventBridgeConfig) applyDefaults() {
+	if c.SubjectPrefix == "" {
+		c.SubjectPrefix = defaultSubjectPrefix
+	}
+	if c.Codec == nil {
+		c.Codec = ProtoCodec
+	}
+	if c.StreamName == "" {
+		c.StreamName = "SUBSCRIPTIONTASKS"
+	}
+}
+
+func (c *EventBridgeConfig) subject(task subtaskapi.SubscriptionTask) string {
+	return fmt.Sprintf("%s.%s.%s", c.SubjectPrefix, task.SubscriptionID, task.EndpointID)
+}
+
+// EventPublisher republishes subscription task events received from a
+// Client's Watch/Subscribe stream onto NATS or JetStream, so sidecars,
+// aggregators, or non-Go consumers can observe task assignment changes
+// without each opening its own gRPC stream to onos-e2sub.
+type EventPublisher struct {
+	config EventBridgeConfig
+	conn   *nats.Conn
+	js     nats.JetStreamContext
+}
+
+// NewEventPublisher connects to NATS and returns an EventPublisher
+func NewEventPublisher(config EventBridgeConfig) (*EventPublisher, error) {
+	config.applyDefaults()
+
+	conn, err := nats.Connect(config.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &EventPublisher{
+		config: config,
+		conn:   conn,
+	}
+
+	if config.JetStream {
+		js, err := conn.JetStream()
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if _, err := js.AddStream(&nats.StreamConfig{
+			Name:     config.StreamName,
+			Subjects: []string{config.SubjectPrefix + ".>"},
+		}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		p.js = js
+	}
+
+	return p, nil
+}
+
+// Publish encodes and publishes a single event
+func (p *EventPublisher) Publish(event subtaskapi.Event) error {
+	data, err := p.config.Codec.Encode(event)
+	if err != nil {
+		return err
+	}
+
+	subject := p.config.subject(event.Task)
+	if p.js != nil {
+		_, err = p.js.Publish(subject, data)
+		return err
+	}
+	return p.conn.Publish(subject, data)
+}
+
+// Forward watches client for subscription task events and republishes each
+// one to NATS until ctx is canceled or the watch stream ends.
+func (p *EventPublisher) Forward(ctx context.Context, client Client, opts ...WatchOption) error {
+	ch := make(chan subtaskapi.Event)
+	if err := client.Watch(ctx, ch, opts...); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := p.Publish(event); err != nil {
+				log.Error("failed to publish subscription task event", err)
+			}
+		}
+	}
+}
+
+// Close closes the underlying NATS connection
+func (p *EventPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
+
+// EventSource is the symmetric counterpart to EventPublisher: it reads
+// subscription task events back from NATS/JetStream, for use in tests and
+// for mirroring events into another region.
+type EventSource struct {
+	config EventBridgeConfig
+	conn   *nats.Conn
+	js     nats.JetStreamContext
+}
+
+// NewEventSource connects to NATS and returns an EventSource
+func NewEventSource(config EventBridgeConfig) (*EventSource, error) {
+	config.applyDefaults()
+
+	conn, err := nats.Connect(config.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &EventSource{
+		config: config,
+		conn:   conn,
+	}
+
+	if config.JetStream {
+		js, err := conn.JetStream()
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		s.js = js
+	}
+
+	return s, nil
+}
+
+// Watch delivers events published to the configured subject prefix to ch
+// until ctx is canceled. When JetStream is enabled, a durable consumer is
+// used so events published while disconnected are replayed on resume.
+func (s *EventSource) Watch(ctx context.Context, ch chan<- subtaskapi.Event) error {
+	subject := s.config.SubjectPrefix + ".>"
+
+	var inFlight sync.WaitGroup
+	decode := func(msg *nats.Msg) {
+		inFlight.Add(1)
+		defer inFlight.Done()
+
+		event, err := s.config.Codec.Decode(msg.Data)
+		if err != nil {
+			log.Error("failed to decode subscription task event", err)
+			return
+		}
+		select {
+		case ch <- event:
+		case <-ctx.Done():
+		}
+	}
+
+	var sub *nats.Subscription
+	var err error
+	if s.js != nil {
+		sub, err = s.js.Subscribe(subject, func(msg *nats.Msg) {
+			decode(msg)
+			_ = msg.Ack()
+		}, nats.Durable("subscriptiontask-mirror"))
+	} else {
+		sub, err = s.conn.Subscribe(subject, decode)
+	}
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		// Unsubscribe stops further callbacks from being dispatched; wait for
+		// any in-flight one to finish before closing ch, since it may still
+		// be sending on it.
+		_ = sub.Unsubscribe()
+		inFlight.Wait()
+		close(ch)
+	}()
+	return nil
+}
+
+// Close closes the underlying NATS connection
+func (s *EventSource) Close() error {
+	s.conn.Close()
+	return nil
+}
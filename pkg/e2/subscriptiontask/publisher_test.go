@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package subscriptiontask
+
+import (
+	"testing"
+
+	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	event := subtaskapi.Event{
+		Type: subtaskapi.EventType_UPDATED,
+		Task: subtaskapi.SubscriptionTask{
+			ID:             "task-1",
+			SubscriptionID: "sub-1",
+			EndpointID:     "ep-1",
+			Revision:       3,
+		},
+	}
+
+	for name, codec := range map[string]Codec{"proto": ProtoCodec, "json": JSONCodec} {
+		t.Run(name, func(t *testing.T) {
+			data, err := codec.Encode(event)
+			assert.NoError(t, err)
+
+			decoded, err := codec.Decode(data)
+			assert.NoError(t, err)
+			assert.Equal(t, event, decoded)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+// Package rest exposes the E2 subscription task client over HTTP+JSON, so
+// xApps written in languages other than Go - or operators using curl - can
+// enumerate subscription tasks without linking the gRPC stubs.
+package rest
+
+import (
+	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
+)
+
+// SubscriptionTask is the JSON representation of a subscription task,
+// matching the schema published in openapi.yaml.
+type SubscriptionTask struct {
+	ID             string `json:"id"`
+	SubscriptionID string `json:"subscriptionId"`
+	EndpointID     string `json:"endpointId"`
+	Revision       uint64 `json:"revision"`
+}
+
+// Event is the JSON representation of a subscription task event, as
+// delivered over the watch SSE stream.
+type Event struct {
+	Type string           `json:"type"`
+	Task SubscriptionTask `json:"task"`
+}
+
+// newSubscriptionTask converts a gRPC SubscriptionTask to its JSON model
+func newSubscriptionTask(task subtaskapi.SubscriptionTask) SubscriptionTask {
+	return SubscriptionTask{
+		ID:             string(task.ID),
+		SubscriptionID: string(task.SubscriptionID),
+		EndpointID:     string(task.EndpointID),
+		Revision:       task.Revision,
+	}
+}
+
+// newEvent converts a gRPC Event to its JSON model
+func newEvent(event subtaskapi.Event) Event {
+	return Event{
+		Type: event.Type.String(),
+		Task: newSubscriptionTask(event.Task),
+	}
+}
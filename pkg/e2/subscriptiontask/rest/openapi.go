@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package rest
+
+// openAPISpec is the OpenAPI 3 document describing this gateway, served at
+// /subscriptions/openapi.yaml and kept in sync with the routes registered
+// in NewServer.
+const openAPISpec = `openapi: 3.0.0
+info:
+  title: onos-e2sub subscription task API
+  description: >
+    REST gateway onto the E2 subscription task gRPC service, for operators
+    and xApps written in languages other than Go.
+  version: 1.0.0
+paths:
+  /subscriptions/tasks:
+    get:
+      summary: List subscription tasks
+      parameters:
+        - name: subscriptionId
+          in: query
+          schema:
+            type: string
+        - name: endpointId
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: the list of subscription tasks
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/SubscriptionTask'
+  /subscriptions/tasks/{id}:
+    get:
+      summary: Get a subscription task by ID
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: the subscription task
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/SubscriptionTask'
+  /subscriptions/tasks/watch:
+    get:
+      summary: Stream subscription task events as server-sent events
+      responses:
+        '200':
+          description: a text/event-stream of Event objects
+          content:
+            text/event-stream:
+              schema:
+                $ref: '#/components/schemas/Event'
+components:
+  schemas:
+    SubscriptionTask:
+      type: object
+      properties:
+        id:
+          type: string
+        subscriptionId:
+          type: string
+        endpointId:
+          type: string
+        revision:
+          type: integer
+          format: uint64
+    Event:
+      type: object
+      properties:
+        type:
+          type: string
+        task:
+          $ref: '#/components/schemas/SubscriptionTask'
+`
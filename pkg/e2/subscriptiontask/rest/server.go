@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	epapi "github.com/onosproject/onos-e2sub/api/e2/endpoint/v1beta1"
+	subapi "github.com/onosproject/onos-e2sub/api/e2/subscription/v1beta1"
+	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/onos-ric-sdk-go/pkg/e2/subscriptiontask"
+	"github.com/gorilla/mux"
+	"golang.org/x/net/context"
+)
+
+var log = logging.GetLogger("e2", "subscription", "rest")
+
+// Server is an HTTP+JSON gateway onto a subscriptiontask.Client
+type Server struct {
+	client subscriptiontask.Client
+	router *mux.Router
+}
+
+// NewServer creates a new REST gateway for client
+func NewServer(client subscriptiontask.Client) *Server {
+	s := &Server{
+		client: client,
+		router: mux.NewRouter(),
+	}
+	s.router.HandleFunc("/subscriptions/tasks", s.listTasks).Methods(http.MethodGet)
+	s.router.HandleFunc("/subscriptions/tasks/watch", s.watchTasks).Methods(http.MethodGet)
+	s.router.HandleFunc("/subscriptions/tasks/{id}", s.getTask).Methods(http.MethodGet)
+	s.router.HandleFunc("/subscriptions/openapi.yaml", s.openAPISpec).Methods(http.MethodGet)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	var opts []subscriptiontask.ListOption
+	if subID := r.URL.Query().Get("subscriptionId"); subID != "" {
+		opts = append(opts, subscriptiontask.WithSubscriptionID(subapi.ID(subID)))
+	}
+	if epID := r.URL.Query().Get("endpointId"); epID != "" {
+		opts = append(opts, subscriptiontask.WithEndpointID(epapi.ID(epID)))
+	}
+
+	tasks, err := s.client.List(r.Context(), opts...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	models := make([]SubscriptionTask, len(tasks))
+	for i, task := range tasks {
+		models[i] = newSubscriptionTask(task)
+	}
+	writeJSON(w, http.StatusOK, models)
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	task, err := s.client.Get(r.Context(), subtaskapi.ID(id))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSubscriptionTask(*task))
+}
+
+// watchTasks streams subscription task events as server-sent events. It
+// relies on Client.Watch closing ch on every exit path (including transient
+// stream errors) so the range loop below ends instead of blocking forever.
+func (s *Server) watchTasks(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := make(chan subtaskapi.Event)
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	if err := s.client.Watch(ctx, ch); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for event := range ch {
+		data, err := json.Marshal(newEvent(event))
+		if err != nil {
+			log.Error("failed to marshal subscription task event", err)
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func (s *Server) openAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(openAPISpec))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("failed to encode response", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	log.Error("subscription task REST request failed", err)
+	writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package subscriptiontask
+
+import (
+	"errors"
+	"sync"
+
+	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
+	"golang.org/x/net/context"
+)
+
+// ErrNotFound is returned by a Store when the requested task is not cached
+var ErrNotFound = errors.New("subscription task not found")
+
+// Store caches the last-known set of subscription tasks and the watch
+// cursor recording how far the client's reconciliation of the store has
+// progressed. Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the cached task with the given ID, or ErrNotFound
+	Get(ctx context.Context, id subtaskapi.ID) (subtaskapi.SubscriptionTask, error)
+
+	// List returns the cached tasks matching opts
+	List(ctx context.Context, opts ...ListOption) ([]subtaskapi.SubscriptionTask, error)
+
+	// Put caches a task
+	Put(ctx context.Context, task subtaskapi.SubscriptionTask) error
+
+	// Delete removes a task from the cache
+	Delete(ctx context.Context, id subtaskapi.ID) error
+
+	// Cursor returns the last watch cursor persisted to the store, or "" if none
+	Cursor(ctx context.Context) (string, error)
+
+	// SetCursor persists the watch cursor
+	SetCursor(ctx context.Context, cursor string) error
+}
+
+// NewMemStore returns a Store that caches tasks in memory. The cache does
+// not survive process restarts and is not shared across client replicas;
+// use an etcd-backed Store (see NewEtcdStore) for that.
+func NewMemStore() Store {
+	return &memStore{
+		tasks: make(map[subtaskapi.ID]subtaskapi.SubscriptionTask),
+	}
+}
+
+type memStore struct {
+	mu     sync.RWMutex
+	tasks  map[subtaskapi.ID]subtaskapi.SubscriptionTask
+	cursor string
+}
+
+func (s *memStore) Get(_ context.Context, id subtaskapi.ID) (subtaskapi.SubscriptionTask, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	task, ok := s.tasks[id]
+	if !ok {
+		return subtaskapi.SubscriptionTask{}, ErrNotFound
+	}
+	return task, nil
+}
+
+func (s *memStore) List(_ context.Context, opts ...ListOption) ([]subtaskapi.SubscriptionTask, error) {
+	options := &listOptions{}
+	for _, opt := range opts {
+		opt.applyList(options)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tasks := make([]subtaskapi.SubscriptionTask, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		if options.subscriptionID != "" && task.SubscriptionID != options.subscriptionID {
+			continue
+		}
+		if options.endpointID != "" && task.EndpointID != options.endpointID {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func (s *memStore) Put(_ context.Context, task subtaskapi.SubscriptionTask) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task.ID] = task
+	return nil
+}
+
+func (s *memStore) Delete(_ context.Context, id subtaskapi.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tasks, id)
+	return nil
+}
+
+func (s *memStore) Cursor(_ context.Context) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cursor, nil
+}
+
+func (s *memStore) SetCursor(_ context.Context, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursor = cursor
+	return nil
+}
+
+var _ Store = &memStore{}
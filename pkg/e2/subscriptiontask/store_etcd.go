@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package subscriptiontask
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
+	clientv3 "go.etcd.io/etcd/clientv3"
+	"golang.org/x/net/context"
+)
+
+// etcdKeyPrefix namespaces subscription task keys within a shared etcd cluster
+const etcdKeyPrefix = "/onos/e2sub/subscriptiontask/"
+
+// etcdTasksSubPath and etcdCursorSubPath are appended to a store's prefix so
+// that two stores configured with distinct KeyPrefix values - the whole
+// point of KeyPrefix - don't share a cursor, and so a prefix-scoped List
+// doesn't also pick up the cursor entry.
+const (
+	etcdTasksSubPath  = "tasks/"
+	etcdCursorSubPath = "cursor"
+)
+
+// EtcdStoreConfig configures NewEtcdStore
+type EtcdStoreConfig struct {
+	// Endpoints is the set of etcd cluster member addresses
+	Endpoints []string
+
+	// KeyPrefix overrides the default key namespace, e.g. to isolate
+	// multiple xApp deployments sharing an etcd cluster.
+	KeyPrefix string
+}
+
+// NewEtcdStore returns a Store backed by etcd v3, allowing multiple xApp
+// replicas to share subscription task cache and watch cursor state for HA.
+func NewEtcdStore(config EtcdStoreConfig) (Store, error) {
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints: config.Endpoints,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := config.KeyPrefix
+	if prefix == "" {
+		prefix = etcdKeyPrefix
+	}
+
+	return &etcdStore{
+		client: cli,
+		prefix: prefix,
+	}, nil
+}
+
+type etcdStore struct {
+	client *clientv3.Client
+	prefix string
+}
+
+func (s *etcdStore) taskPrefix() string {
+	return s.prefix + etcdTasksSubPath
+}
+
+func (s *etcdStore) key(id subtaskapi.ID) string {
+	return fmt.Sprintf("%s%s", s.taskPrefix(), id)
+}
+
+func (s *etcdStore) cursorKey() string {
+	return s.prefix + etcdCursorSubPath
+}
+
+func (s *etcdStore) Get(ctx context.Context, id subtaskapi.ID) (subtaskapi.SubscriptionTask, error) {
+	resp, err := s.client.Get(ctx, s.key(id))
+	if err != nil {
+		return subtaskapi.SubscriptionTask{}, err
+	}
+	if len(resp.Kvs) == 0 {
+		return subtaskapi.SubscriptionTask{}, ErrNotFound
+	}
+
+	var task subtaskapi.SubscriptionTask
+	if err := proto.Unmarshal(resp.Kvs[0].Value, &task); err != nil {
+		return subtaskapi.SubscriptionTask{}, err
+	}
+	return task, nil
+}
+
+func (s *etcdStore) List(ctx context.Context, opts ...ListOption) ([]subtaskapi.SubscriptionTask, error) {
+	options := &listOptions{}
+	for _, opt := range opts {
+		opt.applyList(options)
+	}
+
+	resp, err := s.client.Get(ctx, s.taskPrefix(), clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]subtaskapi.SubscriptionTask, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var task subtaskapi.SubscriptionTask
+		if err := proto.Unmarshal(kv.Value, &task); err != nil {
+			return nil, err
+		}
+		if options.subscriptionID != "" && task.SubscriptionID != options.subscriptionID {
+			continue
+		}
+		if options.endpointID != "" && task.EndpointID != options.endpointID {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func (s *etcdStore) Put(ctx context.Context, task subtaskapi.SubscriptionTask) error {
+	value, err := proto.Marshal(&task)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Put(ctx, s.key(task.ID), string(value))
+	return err
+}
+
+func (s *etcdStore) Delete(ctx context.Context, id subtaskapi.ID) error {
+	_, err := s.client.Delete(ctx, s.key(id))
+	return err
+}
+
+func (s *etcdStore) Cursor(ctx context.Context) (string, error) {
+	resp, err := s.client.Get(ctx, s.cursorKey())
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Kvs) == 0 {
+		return "", nil
+	}
+	return string(resp.Kvs[0].Value), nil
+}
+
+func (s *etcdStore) SetCursor(ctx context.Context, cursor string) error {
+	_, err := s.client.Put(ctx, s.cursorKey(), cursor)
+	return err
+}
+
+var _ Store = &etcdStore{}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package subscriptiontask
+
+import (
+	"testing"
+
+	subapi "github.com/onosproject/onos-e2sub/api/e2/subscription/v1beta1"
+	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestMemStoreGetPutDelete(t *testing.T) {
+	store := NewMemStore()
+	ctx := context.Background()
+
+	_, err := store.Get(ctx, "task-1")
+	assert.Equal(t, ErrNotFound, err)
+
+	task := subtaskapi.SubscriptionTask{ID: "task-1", SubscriptionID: "sub-1"}
+	assert.NoError(t, store.Put(ctx, task))
+
+	got, err := store.Get(ctx, "task-1")
+	assert.NoError(t, err)
+	assert.Equal(t, task, got)
+
+	assert.NoError(t, store.Delete(ctx, "task-1"))
+	_, err = store.Get(ctx, "task-1")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestMemStoreListFilters(t *testing.T) {
+	store := NewMemStore()
+	ctx := context.Background()
+
+	assert.NoError(t, store.Put(ctx, subtaskapi.SubscriptionTask{ID: "task-1", SubscriptionID: "sub-1", EndpointID: "ep-1"}))
+	assert.NoError(t, store.Put(ctx, subtaskapi.SubscriptionTask{ID: "task-2", SubscriptionID: "sub-2", EndpointID: "ep-1"}))
+
+	tasks, err := store.List(ctx, WithSubscriptionID(subapi.ID("sub-1")))
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, subtaskapi.ID("task-1"), tasks[0].ID)
+
+	tasks, err = store.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+}
+
+func TestMemStoreCursor(t *testing.T) {
+	store := NewMemStore()
+	ctx := context.Background()
+
+	cursor, err := store.Cursor(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "", cursor)
+
+	assert.NoError(t, store.SetCursor(ctx, "task-1@3"))
+	cursor, err = store.Cursor(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "task-1@3", cursor)
+}
@@ -0,0 +1,294 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package subscriptiontask
+
+import (
+	"hash/fnv"
+	"io"
+	"sync"
+	"time"
+
+	epapi "github.com/onosproject/onos-e2sub/api/e2/endpoint/v1beta1"
+	subapi "github.com/onosproject/onos-e2sub/api/e2/subscription/v1beta1"
+	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
+	"golang.org/x/net/context"
+)
+
+// defaultMaxOutstanding is the default size of each of Subscribe's event
+// buffers: the one fed by the underlying stream and the queue of each worker.
+// Subscribe applies backpressure to the stream once these are full.
+const defaultMaxOutstanding = 64
+
+// defaultWorkers is the default size of the Subscribe handler worker pool
+const defaultWorkers = 4
+
+// Handler processes a single subscription task event. A returned error does
+// not stop the subscription; it is logged and the next event is dispatched.
+type Handler func(ctx context.Context, event subtaskapi.Event) error
+
+// SubscribeOption is an option for the Subscribe call
+type SubscribeOption interface {
+	applySubscribe(*subscribeOptions)
+}
+
+type subscribeOptions struct {
+	subscriptionID subapi.ID
+	endpointID     epapi.ID
+	maxOutstanding int
+	workers        int
+	ackDeadline    time.Duration
+	retryPolicy    RetryPolicy
+}
+
+// WithMaxOutstanding bounds the number of events buffered awaiting dispatch:
+// up to n events in the stream buffer plus up to n queued for each worker.
+func WithMaxOutstanding(n int) SubscribeOption {
+	return &maxOutstandingOption{n: n}
+}
+
+type maxOutstandingOption struct {
+	n int
+}
+
+func (o *maxOutstandingOption) applySubscribe(options *subscribeOptions) {
+	options.maxOutstanding = o.n
+}
+
+// WithWorkers sets the size of the worker pool used to dispatch events to
+// the handler. Events for the same task ID always go to the same worker,
+// so increasing this only adds concurrency across distinct tasks.
+func WithWorkers(n int) SubscribeOption {
+	return &workersOption{n: n}
+}
+
+type workersOption struct {
+	n int
+}
+
+func (o *workersOption) applySubscribe(options *subscribeOptions) {
+	options.workers = o.n
+}
+
+// WithAckDeadline bounds how long a handler may run on a single event;
+// once it elapses the handler's context is canceled.
+func WithAckDeadline(d time.Duration) SubscribeOption {
+	return &ackDeadlineOption{d: d}
+}
+
+type ackDeadlineOption struct {
+	d time.Duration
+}
+
+func (o *ackDeadlineOption) applySubscribe(options *subscribeOptions) {
+	options.ackDeadline = o.d
+}
+
+// WithSubscribeRetry configures the reconnect backoff used by Subscribe
+func WithSubscribeRetry(policy RetryPolicy) SubscribeOption {
+	return &subscribeRetryOption{policy: policy}
+}
+
+type subscribeRetryOption struct {
+	policy RetryPolicy
+}
+
+func (o *subscribeRetryOption) applySubscribe(options *subscribeOptions) {
+	options.retryPolicy = o.policy
+}
+
+// Subscribe streams subscription task events to handler, transparently
+// reconnecting the underlying gRPC stream on transient errors and resuming
+// from the last-observed state so reconnects do not lose events. It returns
+// immediately with a channel on which a single terminal error is reported
+// when the subscription can no longer continue (ctx canceled, or the retry
+// policy's MaxAttempts reached; every error that ends a session is retried
+// until then); the channel is closed once that error is sent.
+func (c *localClient) Subscribe(ctx context.Context, handler Handler, opts ...SubscribeOption) (<-chan error, error) {
+	options := &subscribeOptions{
+		maxOutstanding: defaultMaxOutstanding,
+		workers:        defaultWorkers,
+		ackDeadline:    30 * time.Second,
+		retryPolicy: RetryPolicy{
+			MaxAttempts:       0, // unlimited
+			InitialBackoff:    500 * time.Millisecond,
+			MaxBackoff:        30 * time.Second,
+			BackoffMultiplier: 2,
+			Jitter:            0.2,
+		},
+	}
+	for _, opt := range opts {
+		opt.applySubscribe(options)
+	}
+
+	errCh := make(chan error, 1)
+	sub := &subscription{
+		client:  c,
+		handler: handler,
+		options: options,
+		known:   make(map[subtaskapi.ID]subtaskapi.SubscriptionTask),
+	}
+	go sub.run(ctx, errCh)
+	return errCh, nil
+}
+
+// subscription tracks the reconnect/resume state of a single Subscribe call.
+// client is the Client interface, not *localClient, so reconcile's diff
+// logic can be exercised in tests against a fake.
+type subscription struct {
+	client  Client
+	handler Handler
+	options *subscribeOptions
+
+	mu    sync.Mutex
+	known map[subtaskapi.ID]subtaskapi.SubscriptionTask
+}
+
+func (s *subscription) run(ctx context.Context, errCh chan<- error) {
+	defer close(errCh)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(s.options.retryPolicy.backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			case <-timer.C:
+			}
+		}
+
+		err := s.runSession(ctx)
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			errCh <- ctx.Err()
+			return
+		}
+		if max := s.options.retryPolicy.MaxAttempts; max > 0 && attempt+1 >= max {
+			errCh <- err
+			return
+		}
+		log.Error("subscribe session ended, reconnecting", err)
+	}
+}
+
+// runSession reconciles local state against the server, then streams events
+// from Watch until the stream ends or errors. Events are fanned out to
+// options.workers goroutines, but every event for a given task ID is routed
+// to the same worker so per-task ordering - e.g. applying a reassignment
+// after the assignment it replaces - is preserved even with workers > 1.
+func (s *subscription) runSession(ctx context.Context) error {
+	if err := s.reconcile(ctx); err != nil {
+		return err
+	}
+
+	ch := make(chan subtaskapi.Event, s.options.maxOutstanding)
+	watchOpts := []WatchOption{}
+	if s.options.subscriptionID != "" {
+		watchOpts = append(watchOpts, WithSubscriptionID(s.options.subscriptionID))
+	}
+	if s.options.endpointID != "" {
+		watchOpts = append(watchOpts, WithEndpointID(s.options.endpointID))
+	}
+
+	if err := s.client.Watch(ctx, ch, watchOpts...); err != nil {
+		return err
+	}
+
+	workers := make([]chan subtaskapi.Event, s.options.workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		worker := make(chan subtaskapi.Event, s.options.maxOutstanding)
+		workers[i] = worker
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range worker {
+				s.dispatch(ctx, event)
+			}
+		}()
+	}
+
+	for event := range ch {
+		workers[taskWorker(event.Task.ID, len(workers))] <- event
+	}
+	for _, worker := range workers {
+		close(worker)
+	}
+	wg.Wait()
+	return io.EOF
+}
+
+// taskWorker deterministically maps a task ID to one of n worker indexes
+func taskWorker(id subtaskapi.ID, n int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return int(h.Sum32() % uint32(n))
+}
+
+// dispatch delivers a single event to the handler and updates local state
+func (s *subscription) dispatch(ctx context.Context, event subtaskapi.Event) {
+	s.mu.Lock()
+	switch event.Type {
+	case subtaskapi.EventType_REMOVED:
+		delete(s.known, event.Task.ID)
+	default:
+		s.known[event.Task.ID] = event.Task
+	}
+	s.mu.Unlock()
+
+	dctx, cancel := context.WithTimeout(ctx, s.options.ackDeadline)
+	defer cancel()
+	if err := s.handler(dctx, event); err != nil {
+		log.Error("subscription task handler returned an error", err)
+	}
+}
+
+// reconcile synthesizes events for changes missed while disconnected by
+// diffing the server's current List against local state: new and changed
+// tasks are reported as UPDATED, tasks no longer listed as REMOVED.
+func (s *subscription) reconcile(ctx context.Context) error {
+	listOpts := []ListOption{}
+	if s.options.subscriptionID != "" {
+		listOpts = append(listOpts, WithSubscriptionID(s.options.subscriptionID))
+	}
+	if s.options.endpointID != "" {
+		listOpts = append(listOpts, WithEndpointID(s.options.endpointID))
+	}
+
+	tasks, err := s.client.List(ctx, listOpts...)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	var events []subtaskapi.Event
+	seen := make(map[subtaskapi.ID]bool, len(tasks))
+	for _, task := range tasks {
+		seen[task.ID] = true
+		if prev, ok := s.known[task.ID]; !ok || prev.Revision != task.Revision {
+			s.known[task.ID] = task
+			events = append(events, subtaskapi.Event{Type: subtaskapi.EventType_UPDATED, Task: task})
+		}
+	}
+	for id, task := range s.known {
+		if !seen[id] {
+			delete(s.known, id)
+			events = append(events, subtaskapi.Event{Type: subtaskapi.EventType_REMOVED, Task: task})
+		}
+	}
+	s.mu.Unlock()
+
+	for _, event := range events {
+		dctx, cancel := context.WithTimeout(ctx, s.options.ackDeadline)
+		err := s.handler(dctx, event)
+		cancel()
+		if err != nil {
+			log.Error("subscription task handler returned an error", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: LicenseRef-ONF-Member-1.0
+
+package subscriptiontask
+
+import (
+	"testing"
+
+	subtaskapi "github.com/onosproject/onos-e2sub/api/e2/task/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+// fakeClient is a minimal Client used to drive reconcile's diff logic
+// without a live gRPC connection.
+type fakeClient struct {
+	Client
+	tasks []subtaskapi.SubscriptionTask
+}
+
+func (f *fakeClient) List(_ context.Context, _ ...ListOption) ([]subtaskapi.SubscriptionTask, error) {
+	return f.tasks, nil
+}
+
+func TestReconcileEmitsUpdatedForNewAndChangedTasks(t *testing.T) {
+	fake := &fakeClient{tasks: []subtaskapi.SubscriptionTask{
+		{ID: "task-1", Revision: 1},
+	}}
+
+	var events []subtaskapi.Event
+	sub := &subscription{
+		client: fake,
+		handler: func(_ context.Context, event subtaskapi.Event) error {
+			events = append(events, event)
+			return nil
+		},
+		options: &subscribeOptions{},
+		known:   make(map[subtaskapi.ID]subtaskapi.SubscriptionTask),
+	}
+
+	assert.NoError(t, sub.reconcile(context.Background()))
+	assert.Len(t, events, 1)
+	assert.Equal(t, subtaskapi.EventType_UPDATED, events[0].Type)
+	assert.Equal(t, subtaskapi.ID("task-1"), events[0].Task.ID)
+
+	events = nil
+	fake.tasks[0].Revision = 2
+	assert.NoError(t, sub.reconcile(context.Background()))
+	assert.Len(t, events, 1)
+	assert.Equal(t, subtaskapi.EventType_UPDATED, events[0].Type)
+	assert.Equal(t, uint64(2), events[0].Task.Revision)
+}
+
+func TestReconcileEmitsRemovedForMissingTasks(t *testing.T) {
+	fake := &fakeClient{}
+
+	var events []subtaskapi.Event
+	sub := &subscription{
+		client: fake,
+		handler: func(_ context.Context, event subtaskapi.Event) error {
+			events = append(events, event)
+			return nil
+		},
+		options: &subscribeOptions{},
+		known: map[subtaskapi.ID]subtaskapi.SubscriptionTask{
+			"task-1": {ID: "task-1", Revision: 1},
+		},
+	}
+
+	assert.NoError(t, sub.reconcile(context.Background()))
+	assert.Len(t, events, 1)
+	assert.Equal(t, subtaskapi.EventType_REMOVED, events[0].Type)
+	assert.Equal(t, subtaskapi.ID("task-1"), events[0].Task.ID)
+	assert.Empty(t, sub.known)
+}
+
+func TestReconcileNoOpWhenNothingChanged(t *testing.T) {
+	fake := &fakeClient{tasks: []subtaskapi.SubscriptionTask{
+		{ID: "task-1", Revision: 1},
+	}}
+
+	var events []subtaskapi.Event
+	sub := &subscription{
+		client: fake,
+		handler: func(_ context.Context, event subtaskapi.Event) error {
+			events = append(events, event)
+			return nil
+		},
+		options: &subscribeOptions{},
+		known: map[subtaskapi.ID]subtaskapi.SubscriptionTask{
+			"task-1": {ID: "task-1", Revision: 1},
+		},
+	}
+
+	assert.NoError(t, sub.reconcile(context.Background()))
+	assert.Empty(t, events)
+}